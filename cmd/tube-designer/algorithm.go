@@ -3,108 +3,348 @@ package main
 import (
 	"fmt"
 	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nextmv-io/sdk/mip"
 )
 
-// optimizeCutting uses the Nextmv MIP API with the HiGHS back-end to find
-// the optimal cutting plan.
-func optimizeCutting(cuts []Cut, stockLen int, kerf float64) Solution {
-	// --- 1. Generate all possible cutting patterns ---
+// lengthScale converts inch lengths into the integer thousandths-of-an-inch
+// units used internally for pattern generation and packing math, so
+// fractional kerf values (e.g. 1/16") aren't rounded away.
+const lengthScale = 1000
+
+// exhaustivePatternThreshold is the number of unique cut lengths at or below
+// which full enumeration (generatePatterns) is fast enough to use directly.
+// Above it, optimizeCutting falls back to column generation.
+const exhaustivePatternThreshold = 8
+
+// columnGenEpsilon is the reduced-cost tolerance below which column
+// generation considers the LP relaxation optimal and stops adding patterns.
+const columnGenEpsilon = 1e-6
+
+// maxColumnGenIterations bounds the column generation loop so a
+// pathological pricing subproblem can't spin forever.
+const maxColumnGenIterations = 500
+
+// Mode selects which search strategy optimizeCutting uses.
+type Mode int
+
+const (
+	// ModeAuto solves exactly via the MIP unless the generated pattern count
+	// would exceed HeuristicThreshold, in which case it falls back to the
+	// sequential heuristic.
+	ModeAuto Mode = iota
+	// ModeExact always solves the MIP, regardless of pattern count.
+	ModeExact
+	// ModeHeuristic always uses the fast sequential heuristic.
+	ModeHeuristic
+)
+
+// heuristicPatternThreshold is the default pattern count above which
+// ModeAuto switches from the exact MIP to the sequential heuristic.
+const heuristicPatternThreshold = 20000
+
+// OptimizeOptions controls how optimizeCutting searches for a cutting plan.
+type OptimizeOptions struct {
+	// ExhaustiveThreshold overrides the unique-cut-length count at or below
+	// which exhaustive pattern enumeration is used instead of column
+	// generation. Zero means use exhaustivePatternThreshold.
+	ExhaustiveThreshold int
+	// Mode selects the search strategy. Zero value is ModeAuto.
+	Mode Mode
+	// HeuristicThreshold overrides the pattern count above which ModeAuto
+	// switches to the heuristic. Zero means use heuristicPatternThreshold.
+	HeuristicThreshold int
+	// TimeLimit bounds how long the MIP solver may search before returning
+	// its best solution so far. Zero means no limit.
+	TimeLimit time.Duration
+	// RelativeGap stops the solver once it has proven the solution is
+	// within this fraction of optimal (e.g. 0.01 for 1%). Zero uses the
+	// solver's own default.
+	RelativeGap float64
+	// Verbose turns on solver progress logging.
+	Verbose bool
+	// WarmStart seeds the MIP with a previously found solution: its
+	// patterns are added to the model (if not already present) and given
+	// as the solver's starting point, so it can improve on a heuristic
+	// result instead of starting from scratch.
+	WarmStart *Solution
+}
+
+// stockPattern is a feasible cutting pattern tied to a specific entry in the
+// stock list, so the master problem can track per-stock availability and
+// cost even when several stock lengths are in play.
+type stockPattern struct {
+	StockIndex int
+	Pieces     []int
+}
+
+// optimizeCutting uses the Nextmv MIP API with the HiGHS back-end to find a
+// cutting plan across one or more stock lengths. For each stock length,
+// small cut lists enumerate every feasible pattern; larger ones build the
+// pattern set incrementally via Gilmore-Gomory column generation, which
+// avoids the combinatorial blow-up of full enumeration.
+func optimizeCutting(cuts []Cut, stocks []Stock, kerf float64, opts ...OptimizeOptions) Solution {
+	opt := OptimizeOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Mode == ModeHeuristic {
+		return optimizeCuttingHeuristic(cuts, stocks, kerf)
+	}
+
+	threshold := opt.ExhaustiveThreshold
+	if threshold <= 0 {
+		threshold = exhaustivePatternThreshold
+	}
+	heuristicThreshold := opt.HeuristicThreshold
+	if heuristicThreshold <= 0 {
+		heuristicThreshold = heuristicPatternThreshold
+	}
+
+	cutDemand := make(map[int]int)
+	for _, cut := range cuts {
+		cutDemand[cut.Length]++
+	}
+	uniqueCuts := make([]int, 0, len(cutDemand))
+	for l := range cutDemand {
+		uniqueCuts = append(uniqueCuts, l)
+	}
 	allCuts := make([]int, len(cuts))
 	for i, c := range cuts {
 		allCuts[i] = c.Length
 	}
-	patterns := generatePatterns(allCuts, stockLen, kerf)
+
+	var patterns []stockPattern
+	for stockIdx, stock := range stocks {
+		var pieces [][]int
+		if len(uniqueCuts) <= threshold {
+			pieces = generatePatterns(allCuts, stock.Length, kerf)
+		} else {
+			pieces = generateColumns(uniqueCuts, cutDemand, stock.Length, kerf)
+		}
+		for _, p := range pieces {
+			patterns = append(patterns, stockPattern{StockIndex: stockIdx, Pieces: p})
+		}
+	}
+
+	if opt.Mode == ModeAuto && len(patterns) > heuristicThreshold {
+		return optimizeCuttingHeuristic(cuts, stocks, kerf)
+	}
+
+	var warmCounts map[string]int
+	if opt.WarmStart != nil {
+		patterns, warmCounts = addWarmStartPatterns(patterns, opt.WarmStart, stocks)
+	}
 	if len(patterns) == 0 {
-		fmt.Println("Error: no valid cutting patterns could be generated.")
+		fmt.Fprintln(os.Stderr, "Error: no valid cutting patterns could be generated.")
 		return Solution{}
 	}
 
-	// --- 2. Build the MIP model ---
+	return solvePatterns(patterns, stocks, cutDemand, kerf, opt, warmCounts)
+}
+
+// patternKey returns a canonical string key for a stock-tied pattern,
+// independent of piece order, so equivalent patterns from different sources
+// (e.g. a warm start versus freshly generated ones) can be matched up.
+func patternKey(stockIdx int, pieces []int) string {
+	sorted := append([]int(nil), pieces...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	parts := make([]string, len(sorted))
+	for i, p := range sorted {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strconv.Itoa(stockIdx) + "|" + strings.Join(parts, "-")
+}
+
+// addWarmStartPatterns folds a previous solution's patterns into the
+// generated pattern set (adding any the exact search didn't already
+// produce) and returns how many sticks the warm start used for each one,
+// keyed the same way as patternKey, so the MIP can be seeded from it.
+// Patterns whose stock length matches more than one stock entry are
+// skipped: a warm-start Solution only carries StockLen, so there's no way
+// to tell which of those entries it actually came from.
+func addWarmStartPatterns(patterns []stockPattern, warmStart *Solution, stocks []Stock) ([]stockPattern, map[string]int) {
+	stockIndex := make(map[int]int, len(stocks))
+	ambiguous := make(map[int]bool)
+	for i, s := range stocks {
+		if _, seen := stockIndex[s.Length]; seen {
+			ambiguous[s.Length] = true
+			continue
+		}
+		stockIndex[s.Length] = i
+	}
+
+	have := make(map[string]bool, len(patterns))
+	for _, p := range patterns {
+		have[patternKey(p.StockIndex, p.Pieces)] = true
+	}
+
+	counts := make(map[string]int)
+	for _, p := range groupPatterns(warmStart.Sticks) {
+		if ambiguous[p.StockLen] {
+			continue
+		}
+		stockIdx, ok := stockIndex[p.StockLen]
+		if !ok {
+			continue
+		}
+		lengths := make([]int, len(p.Cuts))
+		for i, c := range p.Cuts {
+			lengths[i] = c.Length
+		}
+
+		key := patternKey(stockIdx, lengths)
+		counts[key] += p.Count
+		if !have[key] {
+			patterns = append(patterns, stockPattern{StockIndex: stockIdx, Pieces: lengths})
+			have[key] = true
+		}
+	}
+	return patterns, counts
+}
+
+// solvePatterns builds and solves the integer master problem over a fixed
+// set of stock-tied patterns: how many sticks to cut from each one to cover
+// demand, respecting per-stock availability, at minimum cost. When no stock
+// has a cost set, total length used is minimized instead.
+func solvePatterns(patterns []stockPattern, stocks []Stock, cutDemand map[int]int, kerf float64, opt OptimizeOptions, warmCounts map[string]int) Solution {
+	kerfTh := int(math.Round(kerf * lengthScale))
+
+	costBased := false
+	for _, s := range stocks {
+		if s.CostPerInch > 0 {
+			costBased = true
+			break
+		}
+	}
+
 	model := mip.NewModel()
-	model.Objective().SetMinimize() // minimise number of sticks
+	model.Objective().SetMinimize()
 
 	patternVars := make([]mip.Var, len(patterns))
-	for i := range patterns {
+	for i, p := range patterns {
 		patternVars[i] = model.NewInt(0, math.MaxInt64)
-		model.Objective().NewTerm(1.0, patternVars[i]) // cost = 1 per stick
-	}
-
-	// Track demand for each cut length
-	cutDemand := make(map[int]int)
-	for _, cut := range cuts {
-		cutDemand[cut.Length]++
+		stock := stocks[p.StockIndex]
+		coeff := float64(stock.Length)
+		if costBased {
+			coeff = stock.CostPerInch * float64(stock.Length)
+		}
+		model.Objective().NewTerm(coeff, patternVars[i])
 	}
 
-	// Add ≥-demand constraints for every cut length
 	for cutLen, demand := range cutDemand {
 		constr := model.NewConstraint(mip.GreaterThanOrEqual, float64(demand))
 		for i, p := range patterns {
-			count := 0
-			for _, piece := range p {
-				if piece == cutLen {
-					count++
-				}
-			}
-			if count > 0 {
+			if count := patternCount(p.Pieces, cutLen); count > 0 {
 				constr.NewTerm(float64(count), patternVars[i])
 			}
 		}
 	}
 
-	// --- 3. Solve with HiGHS ---
+	for stockIdx, stock := range stocks {
+		if stock.Available <= 0 {
+			continue // 0 or unset means unlimited supply
+		}
+		constr := model.NewConstraint(mip.LessThanOrEqual, float64(stock.Available))
+		for i, p := range patterns {
+			if p.StockIndex == stockIdx {
+				constr.NewTerm(1.0, patternVars[i])
+			}
+		}
+	}
+
+	for i, p := range patterns {
+		if count, ok := warmCounts[patternKey(p.StockIndex, p.Pieces)]; ok {
+			patternVars[i].SetValue(float64(count))
+		}
+	}
+
 	solver, err := mip.NewSolver(mip.Highs, model)
 	if err != nil {
-		fmt.Printf("HiGHS solver init error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "HiGHS solver init error: %v\n", err)
 		return Solution{}
 	}
 
-	solution, err := solver.Solve(mip.SolveOptions{}) // ← only this line changed
+	solveOpts := mip.SolveOptions{
+		Duration: opt.TimeLimit,
+		MIPGap:   opt.RelativeGap,
+		Verbose:  opt.Verbose,
+	}
+
+	solution, err := solver.Solve(solveOpts)
 	if err != nil {
-		fmt.Printf("Solve error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Solve error: %v\n", err)
 		return Solution{}
 	}
 	if !solution.IsOptimal() {
-		fmt.Println("Warning: solver did not prove optimality.")
+		fmt.Fprintln(os.Stderr, "Warning: solver did not prove optimality.")
 	}
 
-	// --- 4. Convert solver output to our domain structs ---
-	result := Solution{}
+	result := Solution{Optimal: solution.IsOptimal(), Gap: solution.Gap()}
 	totalUsed := 0
+	totalStock := 0
 
 	for i, p := range patterns {
 		numSticks := int(math.Round(solution.Value(patternVars[i])))
 		if numSticks == 0 {
 			continue
 		}
+		stock := stocks[p.StockIndex]
 
-		cutSlice := make([]Cut, len(p))
-		usedLen := 0
-		for j, cl := range p {
+		cutSlice := make([]Cut, len(p.Pieces))
+		for j, cl := range p.Pieces {
 			cutSlice[j] = Cut{Length: cl}
-			usedLen += cl
-		}
-		if len(cutSlice) > 1 {
-			usedLen += int(math.Round(float64(len(cutSlice)-1) * kerf))
 		}
+		usedLen := int(math.Round(float64(calculateUsedLength(cutSlice, kerfTh)) / lengthScale))
 
 		for s := 0; s < numSticks; s++ {
 			result.Sticks = append(result.Sticks, Stick{
 				Cuts:     cutSlice,
-				StockLen: stockLen,
+				StockLen: stock.Length,
 				UsedLen:  usedLen,
-				WasteLen: stockLen - usedLen,
+				WasteLen: stock.Length - usedLen,
 			})
 		}
 		totalUsed += usedLen * numSticks
+		totalStock += stock.Length * numSticks
+		result.TotalCost += stock.CostPerInch * float64(stock.Length) * float64(numSticks)
 	}
 
 	result.NumSticks = len(result.Sticks)
-	result.TotalWaste = result.NumSticks*stockLen - totalUsed
+	result.TotalWaste = totalStock - totalUsed
 	return result
 }
 
+// calculateUsedLength returns the total stock consumed by a set of cuts, in
+// thousandths of an inch, including one kerf gap between each pair of
+// adjacent cuts.
+func calculateUsedLength(cuts []Cut, kerfTh int) int {
+	used := 0
+	for _, c := range cuts {
+		used += c.Length * lengthScale
+	}
+	if len(cuts) > 1 {
+		used += kerfTh * (len(cuts) - 1)
+	}
+	return used
+}
+
+// patternCount returns how many times cutLen appears in pattern p.
+func patternCount(p []int, cutLen int) int {
+	count := 0
+	for _, piece := range p {
+		if piece == cutLen {
+			count++
+		}
+	}
+	return count
+}
+
 // generatePatterns finds all possible ways a single stick can be cut.
 func generatePatterns(availableCuts []int, stockLen int, kerf float64) [][]int {
 	uniqueCutsMap := make(map[int]bool)
@@ -119,9 +359,10 @@ func generatePatterns(availableCuts []int, stockLen int, kerf float64) [][]int {
 	var patterns [][]int
 	var currentPattern []int
 	var find func(int, int)
-	kerfInt := int(math.Round(kerf))
+	kerfTh := int(math.Round(kerf * lengthScale))
+	stockLenTh := stockLen * lengthScale
 
-	find = func(startIndex, remainingLen int) {
+	find = func(startIndex, remainingTh int) {
 		if len(currentPattern) > 0 {
 			pCopy := make([]int, len(currentPattern))
 			copy(pCopy, currentPattern)
@@ -130,18 +371,386 @@ func generatePatterns(availableCuts []int, stockLen int, kerf float64) [][]int {
 
 		for i := startIndex; i < len(uniqueCuts); i++ {
 			cut := uniqueCuts[i]
+			cutTh := cut * lengthScale
 			kerfCost := 0
 			if len(currentPattern) > 0 {
-				kerfCost = kerfInt
+				kerfCost = kerfTh
 			}
-			if remainingLen >= cut+kerfCost {
+			if remainingTh >= cutTh+kerfCost {
 				currentPattern = append(currentPattern, cut)
-				find(i, remainingLen-(cut+kerfCost))
+				find(i, remainingTh-(cutTh+kerfCost))
 				currentPattern = currentPattern[:len(currentPattern)-1]
 			}
 		}
 	}
 
-	find(0, stockLen)
+	find(0, stockLenTh)
+	return patterns
+}
+
+// generateColumns builds a compact pattern set via Gilmore-Gomory column
+// generation: start from a trivial one-piece-per-stick basis, solve the LP
+// relaxation of the master problem for dual prices, price out a new
+// pattern with a bounded knapsack subproblem, and repeat until no pattern
+// has negative reduced cost.
+func generateColumns(uniqueCuts []int, demand map[int]int, stockLen int, kerf float64) [][]int {
+	kerfTh := int(math.Round(kerf * lengthScale))
+	stockLenTh := stockLen * lengthScale
+
+	// Only cuts that actually fit this stock length are part of its
+	// sub-problem; a cut too long for this stock is left to whichever other
+	// stock can produce it, enforced by the master MIP's demand constraints.
+	var fittingCuts []int
+	for _, l := range uniqueCuts {
+		if l*lengthScale <= stockLenTh {
+			fittingCuts = append(fittingCuts, l)
+		}
+	}
+
+	patterns := make([][]int, len(fittingCuts))
+	for i, l := range fittingCuts {
+		patterns[i] = []int{l}
+	}
+
+	for iter := 0; iter < maxColumnGenIterations; iter++ {
+		duals, err := solveLPRelaxation(patterns, fittingCuts, demand)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Column generation LP error: %v\n", err)
+			break
+		}
+
+		pattern, value := priceOutPattern(fittingCuts, duals, demand, stockLenTh, kerfTh)
+		if pattern == nil || value <= 1+columnGenEpsilon {
+			break
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
+
+// solveLPRelaxation solves the LP relaxation of the restricted master
+// problem over the current pattern set and returns the dual price y_i for
+// each cut length's demand constraint.
+func solveLPRelaxation(patterns [][]int, uniqueCuts []int, demand map[int]int) (map[int]float64, error) {
+	model := mip.NewModel()
+	model.Objective().SetMinimize()
+
+	patternVars := make([]mip.Var, len(patterns))
+	for i := range patterns {
+		patternVars[i] = model.NewFloat(0, math.MaxFloat64)
+		model.Objective().NewTerm(1.0, patternVars[i])
+	}
+
+	constraints := make(map[int]mip.Constraint, len(uniqueCuts))
+	for _, cutLen := range uniqueCuts {
+		constr := model.NewConstraint(mip.GreaterThanOrEqual, float64(demand[cutLen]))
+		for i, p := range patterns {
+			if count := patternCount(p, cutLen); count > 0 {
+				constr.NewTerm(float64(count), patternVars[i])
+			}
+		}
+		constraints[cutLen] = constr
+	}
+
+	solver, err := mip.NewSolver(mip.Highs, model)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := solver.Solve(mip.SolveOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	duals := make(map[int]float64, len(uniqueCuts))
+	for cutLen, constr := range constraints {
+		duals[cutLen] = solution.DualValue(constr)
+	}
+	return duals, nil
+}
+
+// priceOutPattern solves the bounded knapsack pricing subproblem
+//
+//	max  sum(y_i * x_i)
+//	s.t. sum((l_i+kerf) * x_i) <= stockLen-kerf,  0 <= x_i <= demand_i
+//
+// via dynamic programming over the integer stock length. Every piece is
+// charged one kerf and one more kerf is taken off the capacity, so a
+// priced-out pattern leaves at least two kerf widths unused compared with
+// calculateUsedLength's one-kerf-per-gap count; tighter patterns are not
+// found here. It returns the resulting pattern and its objective value; the reduced
+// cost of that pattern is 1 minus this value.
+func priceOutPattern(uniqueCuts []int, duals map[int]float64, demand map[int]int, stockLenTh, kerfTh int) ([]int, float64) {
+	capacity := stockLenTh - kerfTh
+	if capacity < 0 {
+		return nil, 0
+	}
+
+	best := make([]float64, capacity+1)
+	pick := make([][]int, capacity+1)
+
+	for _, cutLen := range uniqueCuts {
+		weight := cutLen*lengthScale + kerfTh
+		value := duals[cutLen]
+		maxCount := demand[cutLen]
+		if weight <= 0 || maxCount <= 0 {
+			continue
+		}
+
+		used := make([]int, capacity+1)
+		for w := weight; w <= capacity; w++ {
+			if used[w-weight] >= maxCount {
+				continue
+			}
+			candidate := best[w-weight] + value
+			if candidate > best[w] {
+				best[w] = candidate
+				used[w] = used[w-weight] + 1
+				pick[w] = append(append([]int{}, pick[w-weight]...), cutLen)
+			}
+		}
+	}
+
+	bestW := 0
+	for w := 1; w <= capacity; w++ {
+		if best[w] > best[bestW] {
+			bestW = w
+		}
+	}
+	if len(pick[bestW]) == 0 {
+		return nil, best[bestW]
+	}
+	return pick[bestW], best[bestW]
+}
+
+// heuristicPattern is one candidate considered by optimizeCuttingHeuristic:
+// a feasible cutting pattern, its trim loss, and how many times it can be
+// applied right now without exceeding any remaining demand.
+type heuristicPattern struct {
+	pieces     []int
+	trimLoss   int
+	multiplier int
+}
+
+// optimizeCuttingHeuristic implements the generalized sequential trim-loss
+// method: repeatedly pick the longest cut length that still has demand,
+// enumerate every feasible pattern that includes it, and apply the
+// candidate with the least trim loss (ties broken by the highest stock
+// multiplier, then by the fewest parts per stick) as many times as demand
+// and stock availability allow. It trades a small amount of optimality for
+// speed on jobs too large for the exact MIP.
+func optimizeCuttingHeuristic(cuts []Cut, stocks []Stock, kerf float64) Solution {
+	kerfTh := int(math.Round(kerf * lengthScale))
+
+	demand := make(map[int]int)
+	for _, c := range cuts {
+		demand[c.Length]++
+	}
+
+	stockLeft := make([]int, len(stocks))
+	for i, s := range stocks {
+		stockLeft[i] = s.Available
+	}
+
+	result := Solution{}
+	totalUsed := 0
+	totalStockLen := 0
+
+	for remainingDemand(demand) > 0 {
+		length := longestWithDemand(demand)
+		if length == 0 {
+			break
+		}
+
+		candidate, stockIdx, found := bestHeuristicPattern(length, demand, stocks, stockLeft, kerfTh)
+		if !found {
+			// No stock (or no remaining availability) can fit this cut, so
+			// the demand can never be fully satisfied. Report infeasibility
+			// rather than a plan that silently drops it, matching how the
+			// exact path reports an empty Solution when it can't generate
+			// any valid pattern.
+			fmt.Fprintf(os.Stderr, "Error: no stock can fit a %s cut; no feasible plan exists.\n", prettyLen(length))
+			return Solution{}
+		}
+
+		stock := stocks[stockIdx]
+		cutSlice := piecesToCuts(candidate.pieces)
+		usedLen := int(math.Round(float64(calculateUsedLength(cutSlice, kerfTh)) / lengthScale))
+
+		for s := 0; s < candidate.multiplier; s++ {
+			result.Sticks = append(result.Sticks, Stick{
+				Cuts:     cutSlice,
+				StockLen: stock.Length,
+				UsedLen:  usedLen,
+				WasteLen: stock.Length - usedLen,
+			})
+		}
+		for _, l := range candidate.pieces {
+			demand[l] -= candidate.multiplier
+		}
+		if stock.Available > 0 {
+			stockLeft[stockIdx] -= candidate.multiplier
+		}
+
+		totalUsed += usedLen * candidate.multiplier
+		totalStockLen += stock.Length * candidate.multiplier
+		result.TotalCost += stock.CostPerInch * float64(stock.Length) * float64(candidate.multiplier)
+	}
+
+	result.NumSticks = len(result.Sticks)
+	result.TotalWaste = totalStockLen - totalUsed
+	return result
+}
+
+// remainingDemand sums the still-unmet demand across all cut lengths.
+func remainingDemand(demand map[int]int) int {
+	total := 0
+	for _, d := range demand {
+		total += d
+	}
+	return total
+}
+
+// longestWithDemand returns the longest cut length that still has positive
+// demand, or 0 if none do.
+func longestWithDemand(demand map[int]int) int {
+	longest := 0
+	for l, d := range demand {
+		if d > 0 && l > longest {
+			longest = l
+		}
+	}
+	return longest
+}
+
+// bestHeuristicPattern enumerates every feasible pattern that includes
+// `length` across all stock entries with remaining supply, and returns the
+// one with the least trim loss together with the stock it came from and how
+// many times it can be applied.
+func bestHeuristicPattern(length int, demand map[int]int, stocks []Stock, stockLeft []int, kerfTh int) (heuristicPattern, int, bool) {
+	var best heuristicPattern
+	bestStock := -1
+	found := false
+
+	for stockIdx, stock := range stocks {
+		if stock.Available > 0 && stockLeft[stockIdx] <= 0 {
+			continue
+		}
+		for _, pieces := range patternsStartingWith(length, demand, stock.Length, kerfTh) {
+			usedTh := calculateUsedLength(piecesToCuts(pieces), kerfTh)
+			multiplier := patternMultiplier(pieces, demand)
+			if stock.Available > 0 && multiplier > stockLeft[stockIdx] {
+				multiplier = stockLeft[stockIdx]
+			}
+			if multiplier <= 0 {
+				continue
+			}
+
+			candidate := heuristicPattern{
+				pieces:     pieces,
+				trimLoss:   stock.Length*lengthScale - usedTh,
+				multiplier: multiplier,
+			}
+			if !found || betterHeuristicPattern(candidate, best) {
+				best = candidate
+				bestStock = stockIdx
+				found = true
+			}
+		}
+	}
+	return best, bestStock, found
+}
+
+// betterHeuristicPattern reports whether a should be preferred over b:
+// least trim loss, then highest stock multiplier, then fewest parts used.
+func betterHeuristicPattern(a, b heuristicPattern) bool {
+	if a.trimLoss != b.trimLoss {
+		return a.trimLoss < b.trimLoss
+	}
+	if a.multiplier != b.multiplier {
+		return a.multiplier > b.multiplier
+	}
+	return len(a.pieces) < len(b.pieces)
+}
+
+// patternMultiplier returns the maximum number of times pattern can be cut
+// without exceeding any remaining demand for the lengths it contains.
+func patternMultiplier(pieces []int, demand map[int]int) int {
+	counts := make(map[int]int)
+	for _, p := range pieces {
+		counts[p]++
+	}
+	multiplier := math.MaxInt64
+	for l, c := range counts {
+		if m := demand[l] / c; m < multiplier {
+			multiplier = m
+		}
+	}
+	if multiplier == math.MaxInt64 {
+		return 0
+	}
+	return multiplier
+}
+
+// piecesToCuts wraps a pattern's piece lengths as Cuts for the shared
+// calculateUsedLength helper.
+func piecesToCuts(pieces []int) []Cut {
+	cuts := make([]Cut, len(pieces))
+	for i, l := range pieces {
+		cuts[i] = Cut{Length: l}
+	}
+	return cuts
+}
+
+// patternsStartingWith enumerates every feasible pattern for a stick of
+// stockLen that includes at least one piece of `length`, built only from
+// cut lengths no longer than `length` with remaining demand. This mirrors
+// the classic trim-loss ordering: place the current longest piece first,
+// then fill the remainder with same-or-shorter pieces.
+func patternsStartingWith(length int, demand map[int]int, stockLen, kerfTh int) [][]int {
+	var candidates []int
+	for l, d := range demand {
+		if d > 0 && l <= length {
+			candidates = append(candidates, l)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(candidates)))
+
+	stockLenTh := stockLen * lengthScale
+	var patterns [][]int
+	var current []int
+	var includesLength bool
+	var find func(startIndex, remainingTh int)
+
+	find = func(startIndex, remainingTh int) {
+		if includesLength && len(current) > 0 {
+			pCopy := make([]int, len(current))
+			copy(pCopy, current)
+			patterns = append(patterns, pCopy)
+		}
+
+		for i := startIndex; i < len(candidates); i++ {
+			cut := candidates[i]
+			cutTh := cut * lengthScale
+			kerfCost := 0
+			if len(current) > 0 {
+				kerfCost = kerfTh
+			}
+			if remainingTh < cutTh+kerfCost {
+				continue
+			}
+
+			current = append(current, cut)
+			wasIncluded := includesLength
+			if cut == length {
+				includesLength = true
+			}
+			find(i, remainingTh-(cutTh+kerfCost))
+			includesLength = wasIncluded
+			current = current[:len(current)-1]
+		}
+	}
+
+	find(0, stockLenTh)
 	return patterns
 }
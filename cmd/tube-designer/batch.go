@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// batchJob is the JSON job description read from stdin in batch mode.
+type batchJob struct {
+	Tubing       string            `json:"tubing"`
+	StockLengths []batchStockEntry `json:"stockLengths"`
+	Kerf         float64           `json:"kerf"`
+	Cuts         []batchCut        `json:"cuts"`
+	Options      batchOptions      `json:"options"`
+}
+
+// batchStockEntry mirrors Stock for JSON input.
+type batchStockEntry struct {
+	Length    int     `json:"length"`
+	Available int     `json:"available,omitempty"`
+	Cost      float64 `json:"cost,omitempty"`
+}
+
+// batchCut is one cut-length demand entry in a batch job.
+type batchCut struct {
+	Length   int    `json:"length"`
+	Quantity int    `json:"quantity"`
+	Label    string `json:"label,omitempty"`
+}
+
+// batchOptions mirrors OptimizeOptions for JSON input.
+type batchOptions struct {
+	Mode      string         `json:"mode,omitempty"` // "exact", "heuristic", or "auto" (default)
+	TimeLimit float64        `json:"timeLimit,omitempty"`
+	Gap       float64        `json:"gap,omitempty"`
+	WarmStart []batchPattern `json:"warmStart,omitempty"`
+}
+
+// batchPattern is one grouped cutting pattern in the JSON result.
+type batchPattern struct {
+	Count    int   `json:"count"`
+	StockLen int   `json:"stockLen"`
+	Cuts     []int `json:"cuts"`
+	UsedLen  int   `json:"usedLen"`
+	WasteLen int   `json:"wasteLen"`
+}
+
+// batchResult is the JSON solution written to stdout in batch mode.
+type batchResult struct {
+	Sticks         int            `json:"sticks"`
+	TotalWaste     int            `json:"totalWaste"`
+	TotalCost      float64        `json:"totalCost,omitempty"`
+	Efficiency     float64        `json:"efficiency"`
+	Optimal        bool           `json:"optimal"`
+	Gap            float64        `json:"gap,omitempty"`
+	StockUsage     []stockCount   `json:"stockUsage"`
+	Patterns       []batchPattern `json:"patterns"`
+	ElapsedSeconds float64        `json:"elapsedSeconds"`
+	Error          string         `json:"error,omitempty"`
+}
+
+// runBatch reads a batchJob as JSON from r, solves it, and writes the
+// resulting batchResult as JSON to w. It's the non-interactive counterpart
+// to the prompt loop in main, for driving tube-designer from spreadsheets,
+// web front-ends, or shop-floor tooling.
+func runBatch(r io.Reader, w io.Writer) error {
+	var job batchJob
+	if err := json.NewDecoder(r).Decode(&job); err != nil {
+		return fmt.Errorf("decode job: %w", err)
+	}
+
+	stocks := make([]Stock, len(job.StockLengths))
+	for i, s := range job.StockLengths {
+		stocks[i] = Stock{Length: s.Length, Available: s.Available, CostPerInch: s.Cost}
+	}
+	if len(stocks) == 0 {
+		return writeBatchError(w, fmt.Errorf("at least one stock length is required"))
+	}
+
+	var cuts []Cut
+	cutID := 1
+	for _, c := range job.Cuts {
+		for i := 0; i < c.Quantity; i++ {
+			cuts = append(cuts, Cut{Length: c.Length, ID: cutID})
+			cutID++
+		}
+	}
+	if len(cuts) == 0 {
+		return writeBatchError(w, fmt.Errorf("at least one cut is required"))
+	}
+
+	opt := OptimizeOptions{
+		Mode:        parseBatchMode(job.Options.Mode),
+		TimeLimit:   time.Duration(job.Options.TimeLimit * float64(time.Second)),
+		RelativeGap: job.Options.Gap,
+		WarmStart:   batchPatternsToSolution(job.Options.WarmStart),
+	}
+
+	start := time.Now()
+	solution := optimizeCutting(cuts, stocks, job.Kerf, opt)
+	elapsed := time.Since(start)
+
+	return writeBatchResult(w, solution, elapsed)
+}
+
+// batchPatternsToSolution reconstructs a minimal *Solution from a batch
+// job's warm-start patterns, suitable only for seeding OptimizeOptions -
+// it carries sticks (so groupPatterns can recover cuts/stock length/count)
+// and nothing else. Returns nil if patterns is empty, so an absent
+// warmStart leaves OptimizeOptions.WarmStart nil.
+func batchPatternsToSolution(patterns []batchPattern) *Solution {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var sol Solution
+	for _, p := range patterns {
+		cutSlice := make([]Cut, len(p.Cuts))
+		for i, l := range p.Cuts {
+			cutSlice[i] = Cut{Length: l}
+		}
+		for i := 0; i < p.Count; i++ {
+			sol.Sticks = append(sol.Sticks, Stick{
+				Cuts:     cutSlice,
+				StockLen: p.StockLen,
+				UsedLen:  p.UsedLen,
+				WasteLen: p.WasteLen,
+			})
+		}
+	}
+	return &sol
+}
+
+// parseBatchMode maps a batch job's mode string to a Mode, defaulting to
+// ModeAuto for an empty or unrecognized value.
+func parseBatchMode(mode string) Mode {
+	switch mode {
+	case "exact":
+		return ModeExact
+	case "heuristic":
+		return ModeHeuristic
+	default:
+		return ModeAuto
+	}
+}
+
+// writeBatchResult converts a Solution into a batchResult and writes it as
+// JSON to w.
+func writeBatchResult(w io.Writer, solution Solution, elapsed time.Duration) error {
+	totalStock := 0
+	for _, s := range solution.Sticks {
+		totalStock += s.StockLen
+	}
+	efficiency := 0.0
+	if totalStock > 0 {
+		efficiency = float64(totalStock-solution.TotalWaste) / float64(totalStock) * 100
+	}
+
+	var patterns []batchPattern
+	for _, p := range groupPatterns(solution.Sticks) {
+		lengths := make([]int, len(p.Cuts))
+		for i, c := range p.Cuts {
+			lengths[i] = c.Length
+		}
+		patterns = append(patterns, batchPattern{
+			Count:    p.Count,
+			StockLen: p.StockLen,
+			Cuts:     lengths,
+			UsedLen:  p.UsedLen,
+			WasteLen: p.WasteLen,
+		})
+	}
+
+	result := batchResult{
+		Sticks:         solution.NumSticks,
+		TotalWaste:     solution.TotalWaste,
+		TotalCost:      solution.TotalCost,
+		Efficiency:     efficiency,
+		Optimal:        solution.Optimal,
+		Gap:            solution.Gap,
+		StockUsage:     stockCounts(solution.Sticks),
+		Patterns:       patterns,
+		ElapsedSeconds: elapsed.Seconds(),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// writeBatchError writes a batchResult carrying only an error message, so
+// scripting callers get a well-formed JSON response even on failure.
+func writeBatchError(w io.Writer, err error) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(batchResult{Error: err.Error()})
+}
+
+// isTTY reports whether f is attached to an interactive terminal.
+func isTTY(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
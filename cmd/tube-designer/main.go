@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
@@ -25,6 +26,16 @@ type Cut struct {
 	ID     int
 }
 
+// Stock describes one stock length a yard carries: how many sticks are on
+// hand (0 means unlimited) and its cost per inch. When every stock's cost is
+// 0, total length used is minimized instead; a 0 cost next to priced stocks
+// makes that stock free in the objective.
+type Stock struct {
+	Length      int
+	Available   int
+	CostPerInch float64
+}
+
 // Stick represents a stock piece with its cuts
 type Stick struct {
 	Cuts     []Cut
@@ -38,23 +49,79 @@ type Solution struct {
 	Sticks     []Stick
 	TotalWaste int
 	NumSticks  int
+	TotalCost  float64
+	// Optimal reports whether the solver proved this plan optimal. The
+	// sequential heuristic never sets this, since it doesn't prove anything.
+	Optimal bool
+	// Gap is the solver's achieved relative optimality gap (0 when Optimal
+	// is true). Unused by the heuristic.
+	Gap float64
 }
 
 func main() {
+	batch := flag.Bool("batch", false, "read a JSON job from stdin and write a JSON solution to stdout")
+	timeLimit := flag.Duration("time-limit", 0, "maximum time the MIP solver may search (e.g. 30s); 0 means no limit")
+	gap := flag.Float64("gap", 0, "relative optimality gap at which the MIP solver may stop early (e.g. 0.01 for 1%); 0 uses the solver default")
+	flag.Parse()
+
+	if *batch || !isTTY(os.Stdin) {
+		if err := runBatch(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "batch error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	r := bufio.NewReader(os.Stdin)
 	fmt.Printf("--- Tube-Designer %s ---\n", Version)
 
 	// 1. Tubing description
 	tubing := getInput(r, "Tubing type (e.g. 2x2)", "2x2")
 
-	// 2. Stock length
-	stockStr := getInput(r, "Stock length (e.g. 24' or 288)", "24'")
-	stockIn := parseAdvancedLength(stockStr)
-	if stockIn <= 0 {
-		fmt.Fprintln(os.Stderr, "Error: Stock length must be a positive number.")
-		os.Exit(1)
+	// 2. Stock lengths
+	fmt.Println("\nEnter stock as 'length [available] [cost-per-inch]' (e.g., 24' or 20' 40 0.85).")
+	fmt.Println("Available and cost are optional; leave available blank/0 for unlimited supply.")
+	fmt.Println("Press Enter on a blank line to finish.")
+
+	var stocks []Stock
+	maxStockIn := 0
+	for {
+		fmt.Print("→ ")
+		line, _ := r.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		parts := strings.Fields(line)
+		lengthIn := parseAdvancedLength(parts[0])
+		if lengthIn <= 0 {
+			fmt.Println("  ✖ Could not parse stock length.")
+			continue
+		}
+
+		available := 0
+		if len(parts) >= 2 {
+			available, _ = strconv.Atoi(parts[1])
+		}
+		cost := 0.0
+		if len(parts) >= 3 {
+			cost = parseFraction(parts[2])
+		}
+
+		stocks = append(stocks, Stock{Length: lengthIn, Available: available, CostPerInch: cost})
+		if lengthIn > maxStockIn {
+			maxStockIn = lengthIn
+		}
+		fmt.Printf("  ✓ Added %s stock\n", prettyLen(lengthIn))
 	}
-	fmt.Printf("  ✓ Using %s stock\n", prettyLen(stockIn))
+
+	if len(stocks) == 0 {
+		stocks = append(stocks, Stock{Length: parseAdvancedLength("24'")})
+		maxStockIn = stocks[0].Length
+		fmt.Printf("  ✓ Using default %s stock\n", prettyLen(maxStockIn))
+	}
+	stockIn := maxStockIn
 
 	// 3. Kerf size
 	kerfStr := getInput(r, "Kerf/blade thickness (e.g. 1/8 or 0.125)", "1/8")
@@ -123,16 +190,21 @@ func main() {
 	fmt.Printf("\nOptimizing %d total cuts...\n", len(cuts))
 
 	startTime := time.Now()
-	solution := optimizeCutting(cuts, stockIn, kerfIn)
+	solution := optimizeCutting(cuts, stocks, kerfIn, OptimizeOptions{TimeLimit: *timeLimit, RelativeGap: *gap})
 	elapsed := time.Since(startTime)
 
 	fmt.Printf("Optimization finished in %.2f seconds.\n", elapsed.Seconds())
 
+	if solution.NumSticks == 0 {
+		fmt.Println("\nNo feasible cutting plan was found (demand may exceed available stock). Nothing to report.")
+		return
+	}
+
 	// 6. Print results
-	printResults(tubing, stockIn, kerfIn, cuts, solution)
+	printResults(tubing, stocks, kerfIn, cuts, solution)
 
 	htmlFile := "cut_plan.html"
-	if err := generateHTML(htmlFile, tubing, stockIn, kerfIn, cuts, solution); err != nil {
+	if err := generateHTML(htmlFile, tubing, stocks, kerfIn, cuts, solution); err != nil {
 		fmt.Fprintf(os.Stderr, "\nError writing HTML file: %v\n", err)
 	} else {
 		fmt.Printf("\nDetailed cut plan saved to %s\n", htmlFile)
@@ -140,6 +212,13 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Could not open HTML file automatically: %v\n", err)
 		}
 	}
+
+	svgFile := "cut_plan.svg"
+	if err := generateSVG(svgFile, solution, stockIn, kerfIn); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing SVG file: %v\n", err)
+	} else {
+		fmt.Printf("Raw cut diagrams saved to %s\n", svgFile)
+	}
 }
 
 // getInput prompts the user and returns their input, or a default value.
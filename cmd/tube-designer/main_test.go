@@ -104,7 +104,7 @@ func TestOptimizeCutting270Cuts(t *testing.T) {
 	stockLen := 288 // 24' in inches
 	kerf := 0.0625  // 1/16"
 
-	solution := optimizeCutting(cuts, stockLen, kerf)
+	solution := optimizeCutting(cuts, []Stock{{Length: stockLen}}, kerf)
 
 	// Verify basic constraints
 	if solution.NumSticks <= 0 {
@@ -159,7 +159,7 @@ func TestOptimizeCuttingComparisonSmall(t *testing.T) {
 	kerf := 0.125
 
 	// Get optimized solution
-	solution := optimizeCutting(cuts, stockLen, kerf)
+	solution := optimizeCutting(cuts, []Stock{{Length: stockLen}}, kerf)
 
 	// This specific case should fit in 2 sticks optimally:
 	// Stick 1: 120 + 60 + 60 (with kerf)
@@ -186,7 +186,7 @@ func TestLargeCutListPerformance(t *testing.T) {
 	kerf := 0.125
 
 	// This should complete quickly even with 500 cuts
-	solution := optimizeCutting(cuts, stockLen, kerf)
+	solution := optimizeCutting(cuts, []Stock{{Length: stockLen}}, kerf)
 
 	if solution.NumSticks <= 0 {
 		t.Errorf("Invalid solution: NumSticks = %d", solution.NumSticks)
@@ -201,3 +201,135 @@ func TestLargeCutListPerformance(t *testing.T) {
 		t.Errorf("Expected 500 cuts in solution, got %d", totalCuts)
 	}
 }
+
+func TestOptimizeCuttingColumnGeneration(t *testing.T) {
+	// 12 unique cut lengths exceeds exhaustivePatternThreshold (8), so this
+	// exercises generateColumns rather than the exhaustive enumeration path.
+	sizes := []int{100, 92, 84, 76, 68, 60, 52, 44, 36, 28, 20, 12}
+	cuts := []Cut{}
+	cutID := 1
+	for i := 0; i < 240; i++ {
+		cuts = append(cuts, Cut{Length: sizes[i%len(sizes)], ID: cutID})
+		cutID++
+	}
+
+	stockLen := 288
+	kerf := 0.125
+
+	solution := optimizeCutting(cuts, []Stock{{Length: stockLen}}, kerf)
+
+	if solution.NumSticks <= 0 {
+		t.Fatalf("NumSticks should be positive, got %d", solution.NumSticks)
+	}
+
+	totalCuts := 0
+	for _, stick := range solution.Sticks {
+		totalCuts += len(stick.Cuts)
+	}
+	if totalCuts != len(cuts) {
+		t.Errorf("expected %d cuts in solution, got %d", len(cuts), totalCuts)
+	}
+
+	kerfTh := int(kerf * 1000)
+	for i, stick := range solution.Sticks {
+		usedTh := calculateUsedLength(stick.Cuts, kerfTh)
+		if usedTh > stockLen*1000 {
+			t.Errorf("Stick %d overfilled: used %d thousandths, max %d", i, usedTh, stockLen*1000)
+		}
+	}
+}
+
+func TestHeuristicMatchesMIPEfficiency270Cuts(t *testing.T) {
+	cuts := []Cut{}
+	cutID := 1
+
+	for i := 0; i < 80; i++ {
+		cuts = append(cuts, Cut{Length: 60, ID: cutID})
+		cutID++
+	}
+	for i := 0; i < 100; i++ {
+		cuts = append(cuts, Cut{Length: 100, ID: cutID})
+		cutID++
+	}
+	for i := 0; i < 90; i++ {
+		cuts = append(cuts, Cut{Length: 36, ID: cutID})
+		cutID++
+	}
+
+	stocks := []Stock{{Length: 288}}
+	kerf := 0.0625
+
+	mipSolution := optimizeCutting(cuts, stocks, kerf)
+	heuristicSolution := optimizeCuttingHeuristic(cuts, stocks, kerf)
+
+	heuristicCuts := 0
+	for _, stick := range heuristicSolution.Sticks {
+		heuristicCuts += len(stick.Cuts)
+	}
+	if heuristicCuts != 270 {
+		t.Errorf("Expected 270 cuts in heuristic solution, got %d", heuristicCuts)
+	}
+
+	t.Logf("270-cut comparison: MIP=%d sticks, heuristic=%d sticks", mipSolution.NumSticks, heuristicSolution.NumSticks)
+
+	if heuristicSolution.NumSticks < mipSolution.NumSticks {
+		t.Errorf("Heuristic used fewer sticks (%d) than the proven-optimal MIP (%d)", heuristicSolution.NumSticks, mipSolution.NumSticks)
+	}
+	if float64(heuristicSolution.NumSticks) > float64(mipSolution.NumSticks)*1.1 {
+		t.Errorf("Heuristic efficiency too far below MIP: %d sticks vs %d", heuristicSolution.NumSticks, mipSolution.NumSticks)
+	}
+}
+
+func TestHeuristicMatchesMIPEfficiency500Cuts(t *testing.T) {
+	cuts := []Cut{}
+	cutID := 1
+
+	sizes := []int{120, 100, 80, 60, 48, 36, 24}
+	for i := 0; i < 500; i++ {
+		size := sizes[i%len(sizes)]
+		cuts = append(cuts, Cut{Length: size, ID: cutID})
+		cutID++
+	}
+
+	stocks := []Stock{{Length: 288}}
+	kerf := 0.125
+
+	mipSolution := optimizeCutting(cuts, stocks, kerf)
+	heuristicSolution := optimizeCuttingHeuristic(cuts, stocks, kerf)
+
+	heuristicCuts := 0
+	for _, stick := range heuristicSolution.Sticks {
+		heuristicCuts += len(stick.Cuts)
+	}
+	if heuristicCuts != 500 {
+		t.Errorf("Expected 500 cuts in heuristic solution, got %d", heuristicCuts)
+	}
+
+	t.Logf("500-cut comparison: MIP=%d sticks, heuristic=%d sticks", mipSolution.NumSticks, heuristicSolution.NumSticks)
+
+	if float64(heuristicSolution.NumSticks) > float64(mipSolution.NumSticks)*1.1 {
+		t.Errorf("Heuristic efficiency too far below MIP: %d sticks vs %d", heuristicSolution.NumSticks, mipSolution.NumSticks)
+	}
+}
+
+func TestOptimizeCuttingModeHeuristic(t *testing.T) {
+	cuts := []Cut{
+		{Length: 120, ID: 1},
+		{Length: 60, ID: 2},
+		{Length: 60, ID: 3},
+	}
+	stocks := []Stock{{Length: 240}}
+
+	solution := optimizeCutting(cuts, stocks, 0.125, OptimizeOptions{Mode: ModeHeuristic})
+	if solution.NumSticks <= 0 {
+		t.Fatalf("expected a valid solution, got NumSticks=%d", solution.NumSticks)
+	}
+
+	totalCuts := 0
+	for _, stick := range solution.Sticks {
+		totalCuts += len(stick.Cuts)
+	}
+	if totalCuts != len(cuts) {
+		t.Errorf("expected %d cuts, got %d", len(cuts), totalCuts)
+	}
+}
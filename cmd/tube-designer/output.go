@@ -30,35 +30,92 @@ func prettyLen(inches int) string {
 }
 
 // printResults prints a concise summary of the solution to the console
-func printResults(tubing string, stockLen int, kerf float64, cuts []Cut, solution Solution) {
-	totalStock := solution.NumSticks * stockLen
+func printResults(tubing string, stocks []Stock, kerf float64, cuts []Cut, solution Solution) {
+	totalStock := 0
+	for _, s := range solution.Sticks {
+		totalStock += s.StockLen
+	}
 	efficiency := 0.0
 	if totalStock > 0 {
 		efficiency = float64(totalStock-solution.TotalWaste) / float64(totalStock) * 100
 	}
 
+	avgWaste := 0
+	if solution.NumSticks > 0 {
+		avgWaste = solution.TotalWaste / solution.NumSticks
+	}
+
 	fmt.Println("\n--- Cut Optimization Summary ---")
-	fmt.Printf("Material:      %s @ %s\n", tubing, prettyLen(stockLen))
+	fmt.Printf("Material:      %s\n", tubing)
 	fmt.Printf("Sticks Needed: %d\n", solution.NumSticks)
 	fmt.Printf("Efficiency:    %.1f%%\n", efficiency)
 	fmt.Printf("Total Waste:   %s (avg %s per stick)\n",
 		prettyLen(solution.TotalWaste),
-		prettyLen(solution.TotalWaste/solution.NumSticks))
+		prettyLen(avgWaste))
+	if solution.TotalCost > 0 {
+		fmt.Printf("Total Cost:    $%.2f\n", solution.TotalCost)
+	}
+	if status := solverStatus(solution); status != "" {
+		fmt.Printf("Solver Status: %s\n", status)
+	}
 	fmt.Println("---------------------------------")
 
+	// Break sticks down by stock length so the user can see what to buy.
+	fmt.Println("\nSticks By Stock Length:")
+	for _, count := range stockCounts(solution.Sticks) {
+		fmt.Printf("  %2d × %s\n", count.Count, prettyLen(count.StockLen))
+	}
+
 	// Group sticks into patterns for cleaner output
 	patterns := groupPatterns(solution.Sticks)
-	fmt.Println("\nCut Patterns (Qty | Cuts -> Waste):")
+	fmt.Println("\nCut Patterns (Qty | Stock | Cuts -> Waste):")
 	for _, p := range patterns {
 		var cutStrs []string
 		for _, c := range p.Cuts {
 			cutStrs = append(cutStrs, prettyLen(c.Length))
 		}
 		cutList := strings.Join(cutStrs, ", ")
-		fmt.Printf("  %2d × | %s -> %s waste\n", p.Count, cutList, prettyLen(p.WasteLen))
+		fmt.Printf("  %2d × | %s | %s -> %s waste\n", p.Count, prettyLen(p.StockLen), cutList, prettyLen(p.WasteLen))
 	}
 }
 
+// solverStatus describes whether the MIP solver proved the plan optimal or
+// stopped early with a known gap, for display in both the console summary
+// and the HTML report. Returns "" when neither applies (e.g. the sequential
+// heuristic was used, which proves nothing).
+func solverStatus(solution Solution) string {
+	if solution.Optimal {
+		return "optimal"
+	}
+	if solution.Gap > 0 {
+		return fmt.Sprintf("stopped early, %.2f%% gap to optimal", solution.Gap*100)
+	}
+	return ""
+}
+
+// stockCount is the number of sticks consumed from one stock length.
+type stockCount struct {
+	StockLen int `json:"stockLen"`
+	Count    int `json:"count"`
+}
+
+// stockCounts tallies how many sticks were used per stock length, sorted by
+// descending length.
+func stockCounts(sticks []Stick) []stockCount {
+	counts := make(map[int]int)
+	for _, s := range sticks {
+		counts[s.StockLen]++
+	}
+	result := make([]stockCount, 0, len(counts))
+	for length, count := range counts {
+		result = append(result, stockCount{StockLen: length, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StockLen > result[j].StockLen
+	})
+	return result
+}
+
 func plural(n int) string {
 	if n == 1 {
 		return ""
@@ -66,15 +123,17 @@ func plural(n int) string {
 	return "s"
 }
 
-// Pattern groups sticks with identical cuts
+// Pattern groups sticks with identical cuts from the same stock length
 type Pattern struct {
 	Cuts     []Cut
 	Count    int
+	StockLen int
 	UsedLen  int
 	WasteLen int
 }
 
-// groupPatterns combines sticks that have the same sequence of cuts
+// groupPatterns combines sticks that have the same stock length and the
+// same sequence of cuts
 func groupPatterns(sticks []Stick) []Pattern {
 	m := make(map[string]*Pattern)
 	for _, s := range sticks {
@@ -87,7 +146,7 @@ func groupPatterns(sticks []Stick) []Pattern {
 		for _, c := range s.Cuts {
 			parts = append(parts, fmt.Sprintf("%d", c.Length))
 		}
-		key := strings.Join(parts, "-")
+		key := fmt.Sprintf("%d|%s", s.StockLen, strings.Join(parts, "-"))
 
 		if p, ok := m[key]; ok {
 			p.Count++
@@ -95,6 +154,7 @@ func groupPatterns(sticks []Stick) []Pattern {
 			m[key] = &Pattern{
 				Cuts:     s.Cuts,
 				Count:    1,
+				StockLen: s.StockLen,
 				UsedLen:  s.UsedLen,
 				WasteLen: s.WasteLen,
 			}
@@ -117,7 +177,7 @@ func groupPatterns(sticks []Stick) []Pattern {
 }
 
 // generateHTML writes a printable HTML file summarizing the solution
-func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cut, solution Solution) error {
+func generateHTML(filename, tubing string, stocks []Stock, kerf float64, cuts []Cut, solution Solution) error {
 	type cutInstr struct {
 		Mark string
 		Len  string
@@ -125,23 +185,33 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
 
 	type patternData struct {
 		Count   int
+		Stock   string
 		CutList string
 		Used    string
 		Waste   string
 		Instr   []cutInstr
+		SVG     template.HTML
+	}
+
+	type stockRow struct {
+		Stock string
+		Count int
 	}
 
 	type pageData struct {
-		Date       string
-		Tubing     string
-		Stock      string
-		NumSticks  int
-		Kerf       string
-		TotalStock string
-		TotalWaste string
-		Efficiency string
-		AvgWaste   string
-		Patterns   []patternData
+		Date         string
+		Tubing       string
+		Stock        string
+		NumSticks    int
+		Kerf         string
+		TotalStock   string
+		TotalWaste   string
+		TotalCost    string
+		Efficiency   string
+		AvgWaste     string
+		SolverStatus string
+		StockRows    []stockRow
+		Patterns     []patternData
 	}
 
 	// Prepare pattern data for the template
@@ -149,7 +219,15 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
 	patterns := groupPatterns(solution.Sticks)
 	var patData []patternData
 
-	for _, p := range patterns {
+	maxStockLen := 0
+	for _, s := range stocks {
+		if s.Length > maxStockLen {
+			maxStockLen = s.Length
+		}
+	}
+	pxPerInch := svgPixelsPerInch(maxStockLen)
+
+	for i, p := range patterns {
 		var cutStrs []string
 		for _, c := range p.Cuts {
 			cutStrs = append(cutStrs, prettyLen(c.Length))
@@ -158,8 +236,8 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
 
 		runningLen := 0
 		var instr []cutInstr
-		for i, c := range p.Cuts {
-			if i > 0 {
+		for j, c := range p.Cuts {
+			if j > 0 {
 				runningLen += kerfInt / 1000
 			}
 			markAt := runningLen + c.Length
@@ -169,30 +247,53 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
 
 		patData = append(patData, patternData{
 			Count:   p.Count,
+			Stock:   prettyLen(p.StockLen),
 			CutList: cutList,
 			Used:    prettyLen(p.UsedLen),
 			Waste:   prettyLen(p.WasteLen),
 			Instr:   instr,
+			SVG:     template.HTML(patternSVG(p, pxPerInch, kerf, i)),
 		})
 	}
 
-	totalStock := solution.NumSticks * stockLen
+	var stockRows []stockRow
+	for _, c := range stockCounts(solution.Sticks) {
+		stockRows = append(stockRows, stockRow{Stock: prettyLen(c.StockLen), Count: c.Count})
+	}
+
+	totalStock := 0
+	for _, s := range solution.Sticks {
+		totalStock += s.StockLen
+	}
 	efficiency := 0.0
 	if totalStock > 0 {
 		efficiency = float64(totalStock-solution.TotalWaste) / float64(totalStock) * 100
 	}
 
+	stockDesc := make([]string, len(stocks))
+	for i, s := range stocks {
+		stockDesc[i] = prettyLen(s.Length)
+	}
+
+	avgWaste := 0
+	if solution.NumSticks > 0 {
+		avgWaste = solution.TotalWaste / solution.NumSticks
+	}
+
 	data := pageData{
-		Date:       time.Now().Format("2006-01-02"),
-		Tubing:     tubing,
-		Stock:      prettyLen(stockLen),
-		NumSticks:  solution.NumSticks,
-		Kerf:       fmt.Sprintf("%.4f\"", kerf),
-		TotalStock: prettyLen(totalStock),
-		TotalWaste: prettyLen(solution.TotalWaste),
-		Efficiency: fmt.Sprintf("%.1f", efficiency),
-		AvgWaste:   prettyLen(solution.TotalWaste / solution.NumSticks),
-		Patterns:   patData,
+		Date:         time.Now().Format("2006-01-02"),
+		Tubing:       tubing,
+		Stock:        strings.Join(stockDesc, ", "),
+		NumSticks:    solution.NumSticks,
+		Kerf:         fmt.Sprintf("%.4f\"", kerf),
+		TotalStock:   prettyLen(totalStock),
+		TotalWaste:   prettyLen(solution.TotalWaste),
+		TotalCost:    fmt.Sprintf("%.2f", solution.TotalCost),
+		Efficiency:   fmt.Sprintf("%.1f", efficiency),
+		AvgWaste:     prettyLen(avgWaste),
+		SolverStatus: solverStatus(solution),
+		StockRows:    stockRows,
+		Patterns:     patData,
 	}
 
 	const tpl = `<!DOCTYPE html>
@@ -224,6 +325,7 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
     <strong>Material:</strong> {{.Tubing}} @ {{.Stock}}<br>
     <strong>Kerf:</strong> {{.Kerf}}<br>
     <strong>Sticks needed:</strong> {{.NumSticks}} × {{.Stock}}
+    {{if .SolverStatus}}<br><strong>Solver:</strong> {{.SolverStatus}}{{end}}
 </p>
 <h2>Efficiency Summary</h2>
 <ul>
@@ -231,13 +333,22 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
     <li>Total waste: {{.TotalWaste}}</li>
     <li>Material efficiency: {{.Efficiency}}%</li>
     <li>Average waste per stick: {{.AvgWaste}}</li>
+    {{if ne .TotalCost "0.00"}}<li>Total cost: ${{.TotalCost}}</li>{{end}}
 </ul>
+<h2>Sticks By Stock Length</h2>
+<table>
+    <tr><th>Stock</th><th>Qty</th></tr>
+    {{range .StockRows}}
+    <tr><td>{{.Stock}}</td><td>{{.Count}}</td></tr>
+    {{end}}
+</table>
 <h2>Cut Patterns</h2>
 <table>
-    <tr><th>Qty</th><th>Cuts</th><th>Used</th><th>Waste</th></tr>
+    <tr><th>Qty</th><th>Stock</th><th>Cuts</th><th>Used</th><th>Waste</th></tr>
     {{range .Patterns}}
     <tr>
         <td>{{.Count}}</td>
+        <td>{{.Stock}}</td>
         <td>{{.CutList}}</td>
         <td>{{.Used}}</td>
         <td>{{.Waste}}</td>
@@ -245,7 +356,8 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
     {{end}}
 </table>
 {{range $idx, $p := .Patterns}}
-<h3>Pattern {{$idx | inc}}<span class="tag">Qty {{$p.Count}}</span></h3>
+<h3>Pattern {{$idx | inc}}<span class="tag">Qty {{$p.Count}}</span><span class="tag">{{$p.Stock}} stock</span></h3>
+{{$p.SVG}}
 <table>
     <tr><th>#</th><th>Mark At</th><th>Cut Piece</th></tr>
     {{range $i, $c := $p.Instr}}
@@ -267,3 +379,100 @@ func generateHTML(filename, tubing string, stockLen int, kerf float64, cuts []Cu
 
 	return t.Execute(f, data)
 }
+
+// svgTargetWidth is the pixel width a to-scale diagram gets for the longest
+// stock length in play; every pattern then shares the resulting
+// pixel-per-inch scale so waste is visually comparable across patterns.
+const svgTargetWidth = 900.0
+
+// svgBarHeight is the pixel height of each cutting pattern's diagram bar.
+const svgBarHeight = 36
+
+// svgPixelsPerInch returns the shared pixel-per-inch scale for a set of
+// to-scale cut diagrams, sized so the longest stock length fits svgTargetWidth.
+func svgPixelsPerInch(maxStockLen int) float64 {
+	if maxStockLen <= 0 {
+		return 1
+	}
+	return svgTargetWidth / float64(maxStockLen)
+}
+
+// patternSVGBody renders one cutting pattern's bar as SVG markup with no
+// enclosing root element: each cut segment is drawn with its length label,
+// kerf gaps are thin dark bands (omitted when a kerf is half a pixel wide or
+// less at this scale), and the trailing waste is hatched. id must
+// be unique among the patterns rendered together so their hatch-pattern
+// defs don't collide.
+func patternSVGBody(p Pattern, pxPerInch, kerf float64, id int) string {
+	kerfPx := kerf * pxPerInch
+	width := float64(p.StockLen) * pxPerInch
+	hatchID := fmt.Sprintf("waste-hatch-%d", id)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<defs><pattern id="%s" width="6" height="6" patternTransform="rotate(45)" patternUnits="userSpaceOnUse"><line x1="0" y1="0" x2="0" y2="6" stroke="#999" stroke-width="2"/></pattern></defs>`+"\n", hatchID)
+
+	x := 0.0
+	for _, c := range p.Cuts {
+		w := float64(c.Length) * pxPerInch
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="0" width="%.1f" height="%d" fill="#189AB4" stroke="#05445E"/>`+"\n", x, w, svgBarHeight)
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d" text-anchor="middle" font-size="11" fill="#fff">%s</text>`+"\n", x+w/2, svgBarHeight/2+4, prettyLen(c.Length))
+		x += w
+		if kerfPx > 0.5 {
+			fmt.Fprintf(&sb, `<rect x="%.1f" y="0" width="%.1f" height="%d" fill="#05445E"/>`+"\n", x, kerfPx, svgBarHeight)
+			x += kerfPx
+		}
+	}
+	if wasteW := width - x; wasteW > 0.5 {
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="0" width="%.1f" height="%d" fill="url(#%s)"/>`+"\n", x, wasteW, svgBarHeight, hatchID)
+	}
+	fmt.Fprintf(&sb, `<rect x="0" y="0" width="%.1f" height="%d" fill="none" stroke="#C7C7C7"/>`+"\n", width, svgBarHeight)
+	return sb.String()
+}
+
+// patternSVG renders one cutting pattern as a standalone, self-contained
+// to-scale SVG bar, for inline embedding in the HTML report where each
+// pattern is its own independent image.
+func patternSVG(p Pattern, pxPerInch, kerf float64, id int) string {
+	width := float64(p.StockLen) * pxPerInch
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%d" font-family="sans-serif">`+"\n", width, svgBarHeight)
+	sb.WriteString(patternSVGBody(p, pxPerInch, kerf, id))
+	sb.WriteString("</svg>")
+	return sb.String()
+}
+
+// generateSVG writes a standalone file containing a single root <svg> with
+// one to-scale cut diagram per pattern, stacked vertically via a translated
+// <g>, so CAD/vector importers that expect one well-formed document see all
+// patterns. stockLen sets the shared pixel-per-inch scale.
+func generateSVG(filename string, solution Solution, stockLen int, kerf float64) error {
+	patterns := groupPatterns(solution.Sticks)
+	pxPerInch := svgPixelsPerInch(stockLen)
+
+	maxWidth := 0.0
+	for _, p := range patterns {
+		if w := float64(p.StockLen) * pxPerInch; w > maxWidth {
+			maxWidth = w
+		}
+	}
+	totalHeight := len(patterns) * svgBarHeight
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%d" font-family="sans-serif">`+"\n", maxWidth, totalHeight)
+	for i, p := range patterns {
+		fmt.Fprintf(&sb, `<g transform="translate(0, %d)">`+"\n", i*svgBarHeight)
+		sb.WriteString(patternSVGBody(p, pxPerInch, kerf, i))
+		sb.WriteString("</g>\n")
+	}
+	sb.WriteString("</svg>\n")
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(sb.String())
+	return err
+}